@@ -0,0 +1,77 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	api "github.com/comfforts/comff-offers/api/v1"
+)
+
+const defaultReferenceDataTTL = 5 * time.Minute
+
+// referenceDataCache holds the last-known-good GetOfferStatuses/GetOfferTypes
+// responses. Both are effectively static reference data, so every offersClient
+// keeps at most one cached value per call, independent of request shape.
+type referenceDataCache struct {
+	mu sync.RWMutex
+
+	statuses   *api.OfferStatusesResponse
+	statusesAt time.Time
+	types      *api.OfferTypesResponse
+	typesAt    time.Time
+}
+
+func newReferenceDataCache() *referenceDataCache {
+	return &referenceDataCache{}
+}
+
+func (c *referenceDataCache) getStatuses(ttl time.Duration) (*api.OfferStatusesResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.statuses == nil || time.Since(c.statusesAt) >= ttl {
+		return nil, false
+	}
+	return c.statuses, true
+}
+
+func (c *referenceDataCache) staleStatuses() (*api.OfferStatusesResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.statuses, c.statuses != nil
+}
+
+func (c *referenceDataCache) setStatuses(resp *api.OfferStatusesResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses = resp
+	c.statusesAt = time.Now()
+}
+
+func (c *referenceDataCache) getTypes(ttl time.Duration) (*api.OfferTypesResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.types == nil || time.Since(c.typesAt) >= ttl {
+		return nil, false
+	}
+	return c.types, true
+}
+
+func (c *referenceDataCache) staleTypes() (*api.OfferTypesResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.types, c.types != nil
+}
+
+func (c *referenceDataCache) setTypes(resp *api.OfferTypesResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.types = resp
+	c.typesAt = time.Now()
+}
+
+func (c *referenceDataCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses = nil
+	c.types = nil
+}
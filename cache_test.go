@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	api "github.com/comfforts/comff-offers/api/v1"
+)
+
+func TestGetOfferStatusesCacheHitSkipsWire(t *testing.T) {
+	calls := 0
+	fake := &fakeOffersClient{
+		getOfferStatuses: func(ctx context.Context, req *api.OfferStatusesRequest, opts ...grpc.CallOption) (*api.OfferStatusesResponse, error) {
+			calls++
+			return &api.OfferStatusesResponse{Statuses: make([]*api.OfferStatus, 10)}, nil
+		},
+	}
+
+	ofc := &offersClient{
+		client:  fake,
+		opts:    NewDefaultClientOption(),
+		refData: newReferenceDataCache(),
+	}
+
+	for i := 0; i < 5; i++ {
+		resp, err := ofc.GetOfferStatuses(context.Background(), &api.OfferStatusesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Statuses, 10)
+	}
+
+	require.Equal(t, 1, calls)
+}
+
+func TestGetOfferTypesCacheDisabledHitsWireEveryCall(t *testing.T) {
+	calls := 0
+	fake := &fakeOffersClient{
+		getOfferTypes: func(ctx context.Context, req *api.OfferTypesRequest, opts ...grpc.CallOption) (*api.OfferTypesResponse, error) {
+			calls++
+			return &api.OfferTypesResponse{Types: make([]*api.OfferType, 1)}, nil
+		},
+	}
+
+	opts := NewDefaultClientOption()
+	opts.ReferenceDataTTL = 0
+	ofc := &offersClient{
+		client:  fake,
+		opts:    opts,
+		refData: newReferenceDataCache(),
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := ofc.GetOfferTypes(context.Background(), &api.OfferTypesRequest{})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 3, calls)
+}
+
+func TestInvalidateReferenceDataForcesRefetch(t *testing.T) {
+	calls := 0
+	fake := &fakeOffersClient{
+		getOfferStatuses: func(ctx context.Context, req *api.OfferStatusesRequest, opts ...grpc.CallOption) (*api.OfferStatusesResponse, error) {
+			calls++
+			return &api.OfferStatusesResponse{Statuses: make([]*api.OfferStatus, 10)}, nil
+		},
+	}
+
+	ofc := &offersClient{
+		client:  fake,
+		opts:    NewDefaultClientOption(),
+		refData: newReferenceDataCache(),
+	}
+
+	_, err := ofc.GetOfferStatuses(context.Background(), &api.OfferStatusesRequest{})
+	require.NoError(t, err)
+	ofc.InvalidateReferenceData()
+	_, err = ofc.GetOfferStatuses(context.Background(), &api.OfferStatusesRequest{})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, calls)
+}
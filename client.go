@@ -2,14 +2,17 @@ package client
 
 import (
 	"context"
-	"fmt"
-	"os"
+	"crypto/tls"
 	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
 
 	config "github.com/comfforts/comff-config"
 	"github.com/comfforts/logger"
@@ -42,7 +45,14 @@ type Client interface {
 	UpdateOffer(ctx context.Context, req *api.UpdateOfferRequest, opts ...grpc.CallOption) (*api.OfferResponse, error)
 	GetOffer(ctx context.Context, req *api.GetOfferRequest, opts ...grpc.CallOption) (*api.OfferResponse, error)
 	GetOffers(ctx context.Context, req *api.GetOffersRequest, opts ...grpc.CallOption) (*api.OffersResponse, error)
+	// GetScheduleOffers is a thin convenience wrapper over GetOffers scoped
+	// by req.ScheduleId.
+	GetScheduleOffers(ctx context.Context, req *api.GetOffersRequest, opts ...grpc.CallOption) (*api.OffersResponse, error)
 	DeleteOffer(ctx context.Context, req *api.DeleteOfferRequest, opts ...grpc.CallOption) (*api.DeleteResponse, error)
+	StreamOffers(ctx context.Context, req *api.GetOffersRequest, opts ...grpc.CallOption) (OfferIterator, error)
+	StreamOffersPaged(ctx context.Context, req *ListOffersRequest, opts ...grpc.CallOption) (OfferIterator, error)
+	RefreshReferenceData(ctx context.Context) error
+	InvalidateReferenceData()
 	Close() error
 }
 
@@ -51,6 +61,55 @@ type ClientOption struct {
 	KeepAlive        time.Duration
 	KeepAliveTimeout time.Duration
 	Caller           string
+
+	// MaxRetries is the number of retry attempts made for RPCs that fail with
+	// a code in RetryableCodes, on top of the initial attempt.
+	MaxRetries        int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	// Jitter is a fraction (e.g. 0.2 for +/-20%) applied to each computed backoff.
+	Jitter         float64
+	RetryableCodes []codes.Code
+
+	// Endpoints, when set, overrides the OFFERS_SERVICE_HOST/OFFERS_SERVICE_PORT
+	// env lookup. A single "host:port" dials that address directly; more than
+	// one registers a round_robin resolver across all of them.
+	//
+	// Each multi-endpoint NewClient call permanently registers a new resolver
+	// builder in grpc-go's process-global registry (see resolver.go) — there
+	// is no Unregister in grpc-go's resolver package, so that entry lives for
+	// the life of the process even after the client is Closed. Avoid
+	// repeatedly constructing multi-endpoint clients in a hot path (a
+	// reconnect-on-failure loop, dynamic reconfig, a test that calls NewClient
+	// per-case); construct one long-lived client and reuse it instead.
+	Endpoints []string
+	// ResolverScheme names the base of the resolver scheme registered for
+	// multi-endpoint dialing; a unique suffix is appended per client instance
+	// so concurrently constructed clients never collide on the same
+	// registered scheme. Defaults to "offers" when unset.
+	ResolverScheme string
+	// TLSConfig, when set, is used instead of config.SetupTLSConfig's mTLS setup.
+	TLSConfig *tls.Config
+	// Insecure skips TLS entirely, for local integration tests where mTLS
+	// material isn't available.
+	Insecure bool
+	// DialOptions are appended after the transport credentials and load
+	// balancing options the client derives from the fields above.
+	DialOptions []grpc.DialOption
+	// UnhealthyTTL is how long an endpoint sits out of the pick list after a
+	// failed health probe before being re-probed, when len(Endpoints) > 1.
+	// Defaults to 30s.
+	UnhealthyTTL time.Duration
+
+	// ReferenceDataTTL controls how long GetOfferStatuses/GetOfferTypes
+	// responses are served from the in-memory cache before the next call
+	// refetches them. Zero disables the cache. Defaults to 5 minutes.
+	ReferenceDataTTL time.Duration
+	// ServeStaleOnError serves the last-known-good cached reference data,
+	// with a logged warning, when a refetch fails with Unavailable instead
+	// of propagating the error.
+	ServeStaleOnError bool
 }
 
 func NewDefaultClientOption() *ClientOption {
@@ -58,6 +117,17 @@ func NewDefaultClientOption() *ClientOption {
 		DialTimeout:      defaultDialTimeout,
 		KeepAlive:        defaultKeepAlive,
 		KeepAliveTimeout: defaultKeepAliveTimeout,
+
+		MaxRetries:        defaultMaxRetries,
+		InitialBackoff:    defaultInitialBackoff,
+		MaxBackoff:        defaultMaxBackoff,
+		BackoffMultiplier: defaultBackoffMultiplier,
+		Jitter:            defaultJitter,
+		RetryableCodes:    defaultRetryableCodes,
+
+		UnhealthyTTL: defaultUnhealthyTTL,
+
+		ReferenceDataTTL: defaultReferenceDataTTL,
 	}
 }
 
@@ -66,6 +136,10 @@ type offersClient struct {
 	client api.OffersClient
 	conn   *grpc.ClientConn
 	opts   *ClientOption
+	health *healthManager
+
+	refData       *referenceDataCache
+	refreshCancel context.CancelFunc
 }
 
 func NewClient(
@@ -76,30 +150,28 @@ func NewClient(
 		clientOpts.Caller = DefaultClientName
 	}
 
-	tlsConfig, err := config.SetupTLSConfig(&config.ConfigOpts{
-		Target: config.OFFERS_CLIENT,
-	})
-	if err != nil {
-		logger.Error("error setting offers service client TLS", zap.Error(err))
-		return nil, err
-	}
-	tlsCreds := credentials.NewTLS(tlsConfig)
-	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(tlsCreds),
+	var opts []grpc.DialOption
+	if clientOpts.Insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		tlsConfig := clientOpts.TLSConfig
+		if tlsConfig == nil {
+			var err error
+			tlsConfig, err = config.SetupTLSConfig(&config.ConfigOpts{
+				Target: config.OFFERS_CLIENT,
+			})
+			if err != nil {
+				logger.Error("error setting offers service client TLS", zap.Error(err))
+				return nil, err
+			}
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	}
 
-	servicePort := os.Getenv("OFFERS_SERVICE_PORT")
-	if servicePort == "" {
-		servicePort = DEFAULT_SERVICE_PORT
-	}
-	serviceHost := os.Getenv("OFFERS_SERVICE_HOST")
-	if serviceHost == "" {
-		serviceHost = DEFAULT_SERVICE_HOST
-	}
-	serviceAddr := fmt.Sprintf("%s:%s", serviceHost, servicePort)
-	// with load balancer
-	// serviceAddr = fmt.Sprintf("%s:///%s", loadbalance.ShopResolverName, serviceAddr)
-	// serviceAddr = fmt.Sprintf("%s:///%s", "shops", serviceAddr)
+	var resolverCC resolver.ClientConn
+	serviceAddr, lbOpts := dialTarget(clientOpts, func(cc resolver.ClientConn) { resolverCC = cc })
+	opts = append(opts, lbOpts...)
+	opts = append(opts, clientOpts.DialOptions...)
 
 	conn, err := grpc.Dial(serviceAddr, opts...)
 	if err != nil {
@@ -108,13 +180,40 @@ func NewClient(
 	}
 
 	client := api.NewOffersClient(conn)
-	logger.Info("offers client connected", zap.String("host", serviceHost), zap.String("port", servicePort))
-	return &offersClient{
-		client: client,
-		logger: logger,
-		conn:   conn,
-		opts:   clientOpts,
-	}, nil
+	logger.Info("offers client connected", zap.String("target", serviceAddr))
+
+	var health *healthManager
+	if len(clientOpts.Endpoints) > 1 && resolverCC != nil {
+		health = newHealthManager(clientOpts.Endpoints, opts, resolverCC, clientOpts.UnhealthyTTL)
+	}
+
+	refreshCtx, refreshCancel := context.WithCancel(context.Background())
+	ofc := &offersClient{
+		client:        client,
+		logger:        logger,
+		conn:          conn,
+		opts:          clientOpts,
+		health:        health,
+		refData:       newReferenceDataCache(),
+		refreshCancel: refreshCancel,
+	}
+	if clientOpts.ReferenceDataTTL > 0 {
+		go ofc.refreshReferenceDataLoop(refreshCtx)
+	} else {
+		refreshCancel()
+	}
+
+	return ofc, nil
+}
+
+// Endpoints reports the health manager's current view of each configured
+// endpoint. It returns nil when the client was not configured with more
+// than one endpoint.
+func (ofc *offersClient) Endpoints() []EndpointStatus {
+	if ofc.health == nil {
+		return nil
+	}
+	return ofc.health.statuses()
 }
 
 func (ofc *offersClient) GetOfferStatuses(
@@ -122,10 +221,35 @@ func (ofc *offersClient) GetOfferStatuses(
 	req *api.OfferStatusesRequest,
 	opts ...grpc.CallOption,
 ) (*api.OfferStatusesResponse, error) {
+	if ofc.opts.ReferenceDataTTL > 0 {
+		if cached, ok := ofc.refData.getStatuses(ofc.opts.ReferenceDataTTL); ok {
+			return cached, nil
+		}
+	}
+
 	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
 	defer cancel()
 
-	return ofc.client.GetOfferStatuses(ctx, req)
+	var resp *api.OfferStatusesResponse
+	err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		resp, err = ofc.client.GetOfferStatuses(ctx, req)
+		return err
+	})
+	if err != nil {
+		if ofc.opts.ServeStaleOnError && isUnavailable(err) {
+			if stale, ok := ofc.refData.staleStatuses(); ok {
+				ofc.logger.Warn("serving stale offer statuses after RPC error", zap.Error(err))
+				return stale, nil
+			}
+		}
+		return nil, err
+	}
+
+	if ofc.opts.ReferenceDataTTL > 0 {
+		ofc.refData.setStatuses(resp)
+	}
+	return resp, nil
 }
 
 func (ofc *offersClient) GetOfferTypes(
@@ -133,12 +257,99 @@ func (ofc *offersClient) GetOfferTypes(
 	req *api.OfferTypesRequest,
 	opts ...grpc.CallOption,
 ) (*api.OfferTypesResponse, error) {
+	if ofc.opts.ReferenceDataTTL > 0 {
+		if cached, ok := ofc.refData.getTypes(ofc.opts.ReferenceDataTTL); ok {
+			return cached, nil
+		}
+	}
+
+	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
+	defer cancel()
+
+	var resp *api.OfferTypesResponse
+	err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		resp, err = ofc.client.GetOfferTypes(ctx, req)
+		return err
+	})
+	if err != nil {
+		if ofc.opts.ServeStaleOnError && isUnavailable(err) {
+			if stale, ok := ofc.refData.staleTypes(); ok {
+				ofc.logger.Warn("serving stale offer types after RPC error", zap.Error(err))
+				return stale, nil
+			}
+		}
+		return nil, err
+	}
+
+	if ofc.opts.ReferenceDataTTL > 0 {
+		ofc.refData.setTypes(resp)
+	}
+	return resp, nil
+}
+
+// RefreshReferenceData force-refetches GetOfferStatuses and GetOfferTypes
+// and repopulates the cache, regardless of the current entries' age.
+func (ofc *offersClient) RefreshReferenceData(ctx context.Context) error {
 	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
 	defer cancel()
 
-	return ofc.client.GetOfferTypes(ctx, req)
+	var statusesResp *api.OfferStatusesResponse
+	if err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		statusesResp, err = ofc.client.GetOfferStatuses(ctx, &api.OfferStatusesRequest{})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var typesResp *api.OfferTypesResponse
+	if err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		typesResp, err = ofc.client.GetOfferTypes(ctx, &api.OfferTypesRequest{})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	ofc.refData.setStatuses(statusesResp)
+	ofc.refData.setTypes(typesResp)
+	return nil
+}
+
+// InvalidateReferenceData drops any cached GetOfferStatuses/GetOfferTypes
+// responses, so the next call refetches from the wire.
+func (ofc *offersClient) InvalidateReferenceData() {
+	ofc.refData.invalidate()
+}
+
+// refreshReferenceDataLoop keeps the reference data cache warm in the
+// background at ofc.opts.ReferenceDataTTL, until ctx is canceled by Close.
+func (ofc *offersClient) refreshReferenceDataLoop(ctx context.Context) {
+	ticker := time.NewTicker(ofc.opts.ReferenceDataTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ofc.RefreshReferenceData(ctx); err != nil {
+				ofc.logger.Warn("background reference data refresh failed", zap.Error(err))
+			}
+		}
+	}
 }
 
+func isUnavailable(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unavailable
+}
+
+// CreateOffer retries on RetryableCodes and attaches an idempotency key to
+// every attempt so a server-side dedup layer can recognize a retry as the
+// same logical create. The key rides along as gRPC metadata rather than a
+// field on req — see idempotencyKeyMDKey's doc comment for why.
 func (ofc *offersClient) CreateOffer(
 	ctx context.Context,
 	req *api.CreateOfferRequest,
@@ -147,7 +358,18 @@ func (ofc *offersClient) CreateOffer(
 	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
 	defer cancel()
 
-	return ofc.client.CreateOffer(ctx, req)
+	ctx, err := withIdempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *api.OfferResponse
+	err = ofc.retryInvoke(ctx, func() error {
+		var err error
+		resp, err = ofc.client.CreateOffer(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 func (ofc *offersClient) UpdateOffer(
@@ -158,7 +380,13 @@ func (ofc *offersClient) UpdateOffer(
 	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
 	defer cancel()
 
-	return ofc.client.UpdateOffer(ctx, req)
+	var resp *api.OfferResponse
+	err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		resp, err = ofc.client.UpdateOffer(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 func (ofc *offersClient) GetOffer(
@@ -169,7 +397,13 @@ func (ofc *offersClient) GetOffer(
 	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
 	defer cancel()
 
-	return ofc.client.GetOffer(ctx, req)
+	var resp *api.OfferResponse
+	err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		resp, err = ofc.client.GetOffer(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 func (ofc *offersClient) GetOffers(
@@ -180,7 +414,23 @@ func (ofc *offersClient) GetOffers(
 	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
 	defer cancel()
 
-	return ofc.client.GetOffers(ctx, req)
+	var resp *api.OffersResponse
+	err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		resp, err = ofc.client.GetOffers(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// GetScheduleOffers is a thin convenience wrapper over GetOffers scoped by
+// req.ScheduleId.
+func (ofc *offersClient) GetScheduleOffers(
+	ctx context.Context,
+	req *api.GetOffersRequest,
+	opts ...grpc.CallOption,
+) (*api.OffersResponse, error) {
+	return ofc.GetOffers(ctx, req, opts...)
 }
 
 func (ofc *offersClient) DeleteOffer(
@@ -191,10 +441,20 @@ func (ofc *offersClient) DeleteOffer(
 	ctx, cancel := ofc.contextWithOptions(ctx, ofc.opts)
 	defer cancel()
 
-	return ofc.client.DeleteOffer(ctx, req)
+	var resp *api.DeleteResponse
+	err := ofc.retryInvoke(ctx, func() error {
+		var err error
+		resp, err = ofc.client.DeleteOffer(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 func (ofc *offersClient) Close() error {
+	ofc.refreshCancel()
+	if ofc.health != nil {
+		ofc.health.close()
+	}
 	if err := ofc.conn.Close(); err != nil {
 		ofc.logger.Error("error closing offers client connection", zap.Error(err))
 		return err
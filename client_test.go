@@ -2,6 +2,8 @@ package client_test
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -31,10 +33,11 @@ func TestOffersClient(t *testing.T) {
 		t *testing.T,
 		ofc offclient.Client,
 	){
-		"test database setup check, succeeds":    testDatabaseSetup,
-		"test offer CRUD, succeeds":              testOfferCRUD,
-		"duplicate offer test, succeeds":         testDuplicateOffer,
-		"invalid offer creation check, succeeds": testInvalidOfferCreate,
+		"test database setup check, succeeds":             testDatabaseSetup,
+		"test offer CRUD, succeeds":                       testOfferCRUD,
+		"duplicate offer test, succeeds":                  testDuplicateOffer,
+		"invalid offer creation check, succeeds":          testInvalidOfferCreate,
+		"stream offers over a large result set, succeeds": testStreamOffers,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			ofc, teardown := setup(t, logger)
@@ -209,6 +212,65 @@ func testDuplicateOffer(t *testing.T, ofc offclient.Client) {
 	})
 }
 
+func testStreamOffers(t *testing.T, ofc offclient.Client) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const offerCount = 105
+	scheduleId := "t3s1Str3amSch3d41e"
+	ids := make([]string, 0, offerCount)
+	for i := 0; i < offerCount; i++ {
+		or := createOfferTester(t, ofc, &api.CreateOfferRequest{
+			ActorId:       TEST_SHOP_ID,
+			ParticipantId: TEST_COURIER_ID,
+			TransactionId: fmt.Sprintf("%s-%d", TEST_DELIVERY_ID, i),
+			RequestedBy:   TEST_REQSTR,
+			Min:           comffC.F12,
+			Max:           comffC.F15,
+			Duration:      (12 * time.Minute).Nanoseconds(),
+			Distance:      comffC.F10,
+			WorkflowId:    TEST_WKFL_ID,
+			RunId:         TEST_RUN_ID,
+		})
+		ids = append(ids, or.Offer.Id)
+
+		_, err := ofc.UpdateOffer(ctx, &api.UpdateOfferRequest{
+			Id:          or.Offer.Id,
+			Status:      api.OfferStatus_ACCEPT_PARTICIPANT,
+			ScheduleId:  scheduleId,
+			Value:       or.Offer.Max,
+			Min:         or.Offer.Min,
+			Max:         or.Offer.Max,
+			RequestedBy: TEST_REQSTR,
+		})
+		require.NoError(t, err)
+	}
+
+	it, err := ofc.StreamOffersPaged(ctx, &offclient.ListOffersRequest{
+		GetOffersRequest: &api.GetOffersRequest{ScheduleId: scheduleId},
+		PageSize:         10,
+	})
+	require.NoError(t, err)
+	defer it.Close()
+
+	seen := 0
+	for {
+		_, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		seen++
+	}
+	require.Equal(t, offerCount, seen)
+
+	for _, id := range ids {
+		deleteOfferTester(t, ofc, &api.DeleteOfferRequest{Id: id})
+	}
+}
+
 func createOfferTester(t *testing.T, client offclient.Client, cor *api.CreateOfferRequest) *api.OfferResponse {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -0,0 +1,259 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+
+	api "github.com/comfforts/comff-offers/api/v1"
+)
+
+const (
+	defaultUnhealthyTTL = 30 * time.Second
+	probeInterval       = 10 * time.Second
+	probeTimeout        = 2 * time.Second
+)
+
+// EndpointStatus is a point-in-time view of one configured endpoint's health,
+// returned by (*offersClient).Endpoints() for operators to observe balancer
+// state.
+type EndpointStatus struct {
+	Addr      string
+	Healthy   bool
+	LastError error
+	LastCheck time.Time
+}
+
+// probeDialer opens a dedicated probe connection to addr, returning an
+// api.OffersClient to probe with and an io.Closer to release it. It's a seam
+// over grpc.Dial so tests can substitute a fake client without a live
+// listener.
+type probeDialer func(addr string, dialOpts []grpc.DialOption) (api.OffersClient, io.Closer, error)
+
+func defaultProbeDialer(addr string, dialOpts []grpc.DialOption) (api.OffersClient, io.Closer, error) {
+	conn, err := grpc.Dial(addr, dialOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return api.NewOffersClient(conn), conn, nil
+}
+
+// endpointHealth owns a dedicated probe connection to one endpoint and its
+// latest health snapshot.
+type endpointHealth struct {
+	addr string
+
+	mu        sync.Mutex
+	client    api.OffersClient
+	closer    io.Closer
+	healthy   bool
+	lastErr   error
+	lastCheck time.Time
+}
+
+func (eh *endpointHealth) snapshot() EndpointStatus {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	return EndpointStatus{
+		Addr:      eh.addr,
+		Healthy:   eh.healthy,
+		LastError: eh.lastErr,
+		LastCheck: eh.lastCheck,
+	}
+}
+
+func (eh *endpointHealth) update(healthy bool, err error) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	eh.healthy = healthy
+	eh.lastErr = err
+	eh.lastCheck = time.Now()
+}
+
+// ensureClient returns eh's probe client, (re-)dialing it via dial first if
+// an earlier dial never succeeded. This is what lets an endpoint that was
+// unreachable at construction time become healthy later, instead of staying
+// unhealthy forever.
+func (eh *endpointHealth) ensureClient(dial probeDialer, dialOpts []grpc.DialOption) (api.OffersClient, error) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	if eh.client != nil {
+		return eh.client, nil
+	}
+	client, closer, err := dial(eh.addr, dialOpts)
+	if err != nil {
+		return nil, err
+	}
+	eh.client = client
+	eh.closer = closer
+	return client, nil
+}
+
+func (eh *endpointHealth) closeConn() {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	if eh.closer != nil {
+		eh.closer.Close()
+		eh.closer = nil
+		eh.client = nil
+	}
+}
+
+// healthManager probes each configured endpoint on its own goroutine,
+// modeled on etcd clientv3's health balancer, and keeps the resolver's
+// address list limited to the currently healthy set. Unhealthy endpoints
+// sit out for unhealthyTTL before being re-probed and, on success, re-added.
+type healthManager struct {
+	cc           resolver.ClientConn
+	unhealthyTTL time.Duration
+	dial         probeDialer
+	dialOpts     []grpc.DialOption
+	cancel       context.CancelFunc
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointHealth
+}
+
+func newHealthManager(endpoints []string, dialOpts []grpc.DialOption, cc resolver.ClientConn, unhealthyTTL time.Duration) *healthManager {
+	return newHealthManagerWithDialer(endpoints, dialOpts, cc, unhealthyTTL, defaultProbeDialer)
+}
+
+// newHealthManagerWithDialer is newHealthManager with the probe connection
+// factory injectable, so tests can exercise probe/recovery behavior against
+// a fake api.OffersClient instead of a live listener.
+func newHealthManagerWithDialer(endpoints []string, dialOpts []grpc.DialOption, cc resolver.ClientConn, unhealthyTTL time.Duration, dial probeDialer) *healthManager {
+	if unhealthyTTL <= 0 {
+		unhealthyTTL = defaultUnhealthyTTL
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hm := &healthManager{
+		cc:           cc,
+		unhealthyTTL: unhealthyTTL,
+		dial:         dial,
+		dialOpts:     dialOpts,
+		cancel:       cancel,
+		endpoints:    make(map[string]*endpointHealth, len(endpoints)),
+	}
+
+	for _, addr := range endpoints {
+		eh := &endpointHealth{addr: addr, healthy: true}
+		if client, closer, err := dial(addr, dialOpts); err == nil {
+			eh.client = client
+			eh.closer = closer
+		} else {
+			eh.healthy = false
+			eh.lastErr = err
+		}
+		hm.endpoints[addr] = eh
+		go hm.watch(ctx, eh)
+	}
+
+	return hm
+}
+
+// nextProbeInterval is the cooldown watch waits before re-probing: the fast
+// steady-state probeInterval while healthy, or the slower unhealthyTTL once
+// an endpoint has been marked unhealthy.
+func nextProbeInterval(healthy bool, unhealthyTTL time.Duration) time.Duration {
+	if healthy {
+		return probeInterval
+	}
+	return unhealthyTTL
+}
+
+// watch re-probes eh at probeInterval while healthy, and at the slower
+// unhealthyTTL cooldown once it's been marked unhealthy.
+func (hm *healthManager) watch(ctx context.Context, eh *endpointHealth) {
+	for {
+		timer := time.NewTimer(nextProbeInterval(eh.snapshot().Healthy, hm.unhealthyTTL))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			hm.probe(ctx, eh)
+		}
+	}
+}
+
+// probe checks eh's health, (re-)dialing its probe connection first if an
+// earlier dial never succeeded, so an endpoint stays eligible to recover
+// even if it was unreachable when the client was constructed.
+func (hm *healthManager) probe(ctx context.Context, eh *endpointHealth) {
+	wasHealthy := eh.snapshot().Healthy
+
+	client, err := eh.ensureClient(hm.dial, hm.dialOpts)
+	if err != nil {
+		eh.update(false, err)
+		if wasHealthy {
+			hm.publish()
+		}
+		return
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	_, rpcErr := client.GetOfferStatuses(probeCtx, &api.OfferStatusesRequest{})
+	healthy := rpcErr == nil
+	if !healthy {
+		if st, ok := status.FromError(rpcErr); ok && st.Code() != codes.Unavailable && st.Code() != codes.DeadlineExceeded {
+			// any well-formed response, even a business error, proves the endpoint is reachable
+			healthy = true
+		}
+	}
+
+	eh.update(healthy, rpcErr)
+	if healthy != wasHealthy {
+		hm.publish()
+	}
+}
+
+// publish pushes the currently healthy address set to the resolver. If every
+// endpoint is unhealthy, it falls back to the full set rather than leaving
+// the balancer with no targets at all.
+func (hm *healthManager) publish() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	var addrs []resolver.Address
+	for _, eh := range hm.endpoints {
+		if eh.snapshot().Healthy {
+			addrs = append(addrs, resolver.Address{Addr: eh.addr})
+		}
+	}
+	if len(addrs) == 0 {
+		for addr := range hm.endpoints {
+			addrs = append(addrs, resolver.Address{Addr: addr})
+		}
+	}
+	hm.cc.UpdateState(resolver.State{Addresses: addrs})
+}
+
+func (hm *healthManager) statuses() []EndpointStatus {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	statuses := make([]EndpointStatus, 0, len(hm.endpoints))
+	for _, eh := range hm.endpoints {
+		statuses = append(statuses, eh.snapshot())
+	}
+	return statuses
+}
+
+func (hm *healthManager) close() {
+	hm.cancel()
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	for _, eh := range hm.endpoints {
+		eh.closeConn()
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+
+	api "github.com/comfforts/comff-offers/api/v1"
+)
+
+// fakeResolverClientConn captures the address sets healthManager publishes,
+// so tests can assert an unhealthy endpoint is excluded from (and later
+// re-included in) the published set without any real resolver wiring.
+type fakeResolverClientConn struct {
+	resolver.ClientConn
+
+	mu    sync.Mutex
+	addrs []string
+}
+
+func (f *fakeResolverClientConn) UpdateState(state resolver.State) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addrs = nil
+	for _, a := range state.Addresses {
+		f.addrs = append(f.addrs, a.Addr)
+	}
+	return nil
+}
+
+func (f *fakeResolverClientConn) snapshot() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.addrs...)
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// TestHealthManagerEjectsAndRecoversUnhealthyEndpoint fails a probe via a
+// fake api.OffersClient and asserts the endpoint drops out of the published
+// address set, then recovers once the fake starts answering again.
+func TestHealthManagerEjectsAndRecoversUnhealthyEndpoint(t *testing.T) {
+	const (
+		healthyAddr   = "10.0.0.1:57051"
+		unhealthyAddr = "10.0.0.2:57051"
+	)
+
+	var mu sync.Mutex
+	up := map[string]bool{healthyAddr: true, unhealthyAddr: true}
+
+	dial := func(addr string, _ []grpc.DialOption) (api.OffersClient, io.Closer, error) {
+		fake := &fakeOffersClient{
+			getOfferStatuses: func(ctx context.Context, req *api.OfferStatusesRequest, opts ...grpc.CallOption) (*api.OfferStatusesResponse, error) {
+				mu.Lock()
+				ok := up[addr]
+				mu.Unlock()
+				if !ok {
+					return nil, status.Error(codes.Unavailable, "simulated down")
+				}
+				return &api.OfferStatusesResponse{}, nil
+			},
+		}
+		return fake, nopCloser{}, nil
+	}
+
+	cc := &fakeResolverClientConn{}
+	hm := newHealthManagerWithDialer([]string{healthyAddr, unhealthyAddr}, nil, cc, 20*time.Millisecond, dial)
+	defer hm.close()
+
+	hm.publish()
+	require.ElementsMatch(t, []string{healthyAddr, unhealthyAddr}, cc.snapshot())
+
+	mu.Lock()
+	up[unhealthyAddr] = false
+	mu.Unlock()
+
+	eh := hm.endpoints[unhealthyAddr]
+	hm.probe(context.Background(), eh)
+
+	require.ElementsMatch(t, []string{healthyAddr}, cc.snapshot())
+	for _, s := range hm.statuses() {
+		if s.Addr == unhealthyAddr {
+			require.False(t, s.Healthy)
+			require.Error(t, s.LastError)
+		}
+	}
+
+	mu.Lock()
+	up[unhealthyAddr] = true
+	mu.Unlock()
+
+	hm.probe(context.Background(), eh)
+	require.ElementsMatch(t, []string{healthyAddr, unhealthyAddr}, cc.snapshot())
+}
+
+// TestHealthManagerRetriesDialOnEveryProbe asserts an endpoint whose initial
+// probe connection never dialed successfully gets a fresh dial attempt on
+// each probe, and so becomes healthy once the server is reachable instead of
+// staying unhealthy forever.
+func TestHealthManagerRetriesDialOnEveryProbe(t *testing.T) {
+	const addr = "10.0.0.3:57051"
+
+	var dialAttempts int
+	var mu sync.Mutex
+	reachable := false
+
+	dial := func(addr string, _ []grpc.DialOption) (api.OffersClient, io.Closer, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dialAttempts++
+		if !reachable {
+			return nil, nil, status.Error(codes.Unavailable, "dial failed")
+		}
+		fake := &fakeOffersClient{
+			getOfferStatuses: func(ctx context.Context, req *api.OfferStatusesRequest, opts ...grpc.CallOption) (*api.OfferStatusesResponse, error) {
+				return &api.OfferStatusesResponse{}, nil
+			},
+		}
+		return fake, nopCloser{}, nil
+	}
+
+	cc := &fakeResolverClientConn{}
+	hm := newHealthManagerWithDialer([]string{addr}, nil, cc, time.Second, dial)
+	defer hm.close()
+
+	eh := hm.endpoints[addr]
+	require.False(t, eh.snapshot().Healthy)
+	require.Equal(t, 1, dialAttempts)
+
+	hm.probe(context.Background(), eh)
+	require.False(t, eh.snapshot().Healthy)
+	require.Equal(t, 2, dialAttempts)
+
+	mu.Lock()
+	reachable = true
+	mu.Unlock()
+
+	hm.probe(context.Background(), eh)
+	require.True(t, eh.snapshot().Healthy)
+	require.Equal(t, 3, dialAttempts)
+}
+
+// TestNextProbeIntervalUsesCooldownWhenUnhealthy documents watch's interval
+// selection: healthy endpoints get the fast steady-state probeInterval,
+// unhealthy ones sit out the full unhealthyTTL cooldown before the next
+// probe.
+func TestNextProbeIntervalUsesCooldownWhenUnhealthy(t *testing.T) {
+	require.Equal(t, probeInterval, nextProbeInterval(true, 30*time.Second))
+	require.Equal(t, 30*time.Second, nextProbeInterval(false, 30*time.Second))
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// idempotencyKeyMDKey is the outgoing metadata header CreateOffer attaches
+// its idempotency key under, for a server-side dedup layer to key replay
+// detection on instead of (ActorId, ParticipantId, TransactionId) alone.
+//
+// This is a narrower contract than a proto-level IdempotencyKey field on
+// api.CreateOfferRequest would be: a dedup layer has to specifically opt
+// into reading gRPC metadata rather than the request body, and the key
+// never reaches any layer (logging, storage) that only sees the decoded
+// request. This client doesn't own api.CreateOfferRequest
+// (github.com/comfforts/comff-offers), so metadata is what's available
+// today; a request-body field needs a proto and server change in that repo.
+const idempotencyKeyMDKey = "x-idempotency-key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that makes CreateOffer send key as
+// its idempotency key instead of generating a random one, and reuse it
+// across every retry attempt. Callers that already have a natural dedup
+// key — e.g. a Temporal WorkflowId+RunId — should derive key from that, so a
+// workflow retried after a crash replays the original CreateOffer instead of
+// failing with AlreadyExists.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// newIdempotencyKey generates a random UUIDv4-shaped key for calls that
+// don't supply their own via WithIdempotencyKey.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// withIdempotencyKey resolves the idempotency key for a CreateOffer call —
+// the caller-supplied one from ctx if present, otherwise a freshly generated
+// one — and attaches it to ctx's outgoing metadata so it rides along with
+// every retry attempt made against that same ctx.
+func withIdempotencyKey(ctx context.Context) (context.Context, error) {
+	key, ok := idempotencyKeyFromContext(ctx)
+	if !ok {
+		var err error
+		key, err = newIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return metadata.AppendToOutgoingContext(ctx, idempotencyKeyMDKey, key), nil
+}
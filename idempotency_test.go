@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	api "github.com/comfforts/comff-offers/api/v1"
+)
+
+// fakeOffersClient lets CreateOffer's retry/idempotency wiring be exercised
+// without a live offers service; only the methods under test need overrides.
+type fakeOffersClient struct {
+	api.OffersClient
+	createOffer      func(ctx context.Context, req *api.CreateOfferRequest, opts ...grpc.CallOption) (*api.OfferResponse, error)
+	getOfferStatuses func(ctx context.Context, req *api.OfferStatusesRequest, opts ...grpc.CallOption) (*api.OfferStatusesResponse, error)
+	getOfferTypes    func(ctx context.Context, req *api.OfferTypesRequest, opts ...grpc.CallOption) (*api.OfferTypesResponse, error)
+}
+
+func (f *fakeOffersClient) CreateOffer(ctx context.Context, req *api.CreateOfferRequest, opts ...grpc.CallOption) (*api.OfferResponse, error) {
+	return f.createOffer(ctx, req, opts...)
+}
+
+func (f *fakeOffersClient) GetOfferStatuses(ctx context.Context, req *api.OfferStatusesRequest, opts ...grpc.CallOption) (*api.OfferStatusesResponse, error) {
+	return f.getOfferStatuses(ctx, req, opts...)
+}
+
+func (f *fakeOffersClient) GetOfferTypes(ctx context.Context, req *api.OfferTypesRequest, opts ...grpc.CallOption) (*api.OfferTypesResponse, error) {
+	return f.getOfferTypes(ctx, req, opts...)
+}
+
+// TestCreateOfferRetryReusesIdempotencyKey simulates a transient timeout on
+// the first attempt and asserts the retry carries the same x-idempotency-key
+// header, so a server-side dedup layer can recognize the replay.
+func TestCreateOfferRetryReusesIdempotencyKey(t *testing.T) {
+	var seenKeys []string
+	fake := &fakeOffersClient{
+		createOffer: func(ctx context.Context, req *api.CreateOfferRequest, opts ...grpc.CallOption) (*api.OfferResponse, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			keys := md.Get(idempotencyKeyMDKey)
+			require.Len(t, keys, 1)
+			seenKeys = append(seenKeys, keys[0])
+
+			if len(seenKeys) == 1 {
+				return nil, status.Error(codes.Unavailable, "simulated timeout")
+			}
+			return &api.OfferResponse{Offer: &api.Offer{Id: "offer-1"}}, nil
+		},
+	}
+
+	ofc := &offersClient{
+		client: fake,
+		opts:   NewDefaultClientOption(),
+	}
+
+	resp, err := ofc.CreateOffer(context.Background(), &api.CreateOfferRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "offer-1", resp.Offer.Id)
+	require.Len(t, seenKeys, 2)
+	require.Equal(t, seenKeys[0], seenKeys[1])
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	api "github.com/comfforts/comff-offers/api/v1"
+)
+
+// DefaultStreamPageSize bounds how many offers offerIterator holds from a
+// single GetOffers call before re-fetching, when ListOffersRequest.PageSize
+// is unset.
+const DefaultStreamPageSize = int32(100)
+
+// ListOffersRequest augments an api.GetOffersRequest with client-driven
+// paging. The offers service this client talks to
+// (github.com/comfforts/comff-offers) has neither a page-token/page-size
+// unary RPC nor a streaming RPC today, so there is no way to ask the wire
+// for fewer results than match req — StreamOffers still has to call
+// GetOffers to get them. PageSize instead bounds how many offers the
+// iterator holds from one GetOffers response before it re-fetches and skips
+// past PageToken for the next batch, so a long iteration isn't pinned to the
+// entire matching result set in memory for its whole lifetime, only one
+// page's worth at a time. PageToken, the Id of the last offer consumed, lets
+// a caller resume a later StreamOffersPaged call where an earlier one left off.
+type ListOffersRequest struct {
+	*api.GetOffersRequest
+	PageSize  int32
+	PageToken string
+}
+
+// OfferIterator yields offers one at a time, honoring ctx cancellation.
+// Next returns io.EOF once exhausted. Close releases resources held by the
+// iterator and should be called even after Next returns io.EOF.
+type OfferIterator interface {
+	Next() (*api.Offer, error)
+	Close()
+}
+
+// offerIterator re-fetches req via GetOffers at each page boundary and
+// slices out the next pageSize offers after pageToken, releasing its
+// reference to each offer as soon as Next returns it, so it only ever keeps
+// one page's worth referenced at a time.
+type offerIterator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	ofc      *offersClient
+	req      *api.GetOffersRequest
+	opts     []grpc.CallOption
+	pageSize int32
+
+	mu        sync.Mutex
+	page      []*api.Offer
+	idx       int
+	pageToken string
+	done      bool
+	closed    bool
+}
+
+func (it *offerIterator) Next() (*api.Offer, error) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.closed {
+		return nil, io.EOF
+	}
+	select {
+	case <-it.ctx.Done():
+		return nil, it.ctx.Err()
+	default:
+	}
+
+	for it.idx >= len(it.page) {
+		if it.done {
+			return nil, io.EOF
+		}
+		if err := it.fetchPage(); err != nil {
+			return nil, err
+		}
+	}
+
+	o := it.page[it.idx]
+	it.page[it.idx] = nil
+	it.idx++
+	it.pageToken = o.Id
+	return o, nil
+}
+
+// fetchPage re-fetches req's full matching result set via GetOffers — the
+// only query this RPC supports — and slices out the next pageSize offers
+// after pageToken.
+func (it *offerIterator) fetchPage() error {
+	resp, err := it.ofc.GetOffers(it.ctx, it.req, it.opts...)
+	if err != nil {
+		return err
+	}
+
+	offers := resp.Offers
+	if it.pageToken != "" {
+		for i, o := range offers {
+			if o.Id == it.pageToken {
+				offers = offers[i+1:]
+				break
+			}
+		}
+	}
+
+	if int32(len(offers)) <= it.pageSize {
+		it.done = true
+	} else {
+		offers = offers[:it.pageSize]
+	}
+
+	// copy the page out so the rest of resp.Offers isn't kept alive by it
+	it.page = make([]*api.Offer, len(offers))
+	copy(it.page, offers)
+	it.idx = 0
+	return nil
+}
+
+func (it *offerIterator) Close() {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.closed {
+		return
+	}
+	it.closed = true
+	it.cancel()
+}
+
+// StreamOffers fetches req's matching offers via GetOffers, re-paging at
+// DefaultStreamPageSize, and returns an iterator over them. opts is passed
+// through to the underlying calls.
+func (ofc *offersClient) StreamOffers(ctx context.Context, req *api.GetOffersRequest, opts ...grpc.CallOption) (OfferIterator, error) {
+	return ofc.StreamOffersPaged(ctx, &ListOffersRequest{GetOffersRequest: req, PageSize: DefaultStreamPageSize}, opts...)
+}
+
+// StreamOffersPaged is StreamOffers with explicit control over the paging
+// knobs in req, for callers that want a smaller page size or want to resume
+// from a prior PageToken.
+func (ofc *offersClient) StreamOffersPaged(ctx context.Context, req *ListOffersRequest, opts ...grpc.CallOption) (OfferIterator, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultStreamPageSize
+	}
+
+	return &offerIterator{
+		ctx:       streamCtx,
+		cancel:    cancel,
+		ofc:       ofc,
+		req:       req.GetOffersRequest,
+		opts:      opts,
+		pageSize:  pageSize,
+		pageToken: req.PageToken,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+)
+
+const defaultResolverScheme = "offers"
+
+// resolverRegisterMu serializes our own calls into resolver.Register, whose
+// package-level builder map isn't safe for concurrent writes. resolverSeq
+// makes every registered scheme unique per *NewClient* call, since
+// resolver.Register documents "if multiple resolvers are registered with the
+// same name, the one registered last will take effect" — two multi-endpoint
+// clients (or the same app restarting a client) sharing ClientOption's
+// zero-valued ResolverScheme would otherwise race to hand each other's
+// address sets to the wrong ClientConn.
+var (
+	resolverRegisterMu sync.Mutex
+	resolverSeq        uint64
+)
+
+// uniqueResolverScheme appends a process-wide unique suffix to requested (or
+// defaultResolverScheme, if requested is empty) so each dialTarget call gets
+// its own scheme, never colliding with another client instance's.
+func uniqueResolverScheme(requested string) string {
+	base := requested
+	if base == "" {
+		base = defaultResolverScheme
+	}
+	return fmt.Sprintf("%s-%d", base, atomic.AddUint64(&resolverSeq, 1))
+}
+
+// staticResolverBuilder resolves to a fixed set of addresses handed to it at
+// registration time. It backs ClientOption.Endpoints multi-endpoint dialing,
+// letting gRPC's round_robin balancer spread calls across the offers service
+// replicas instead of pinning to a single resolved address.
+//
+// onBuild, when set, is handed the resolver.ClientConn produced for this
+// dial so a healthManager can later push updated (healthy-only) address
+// sets through the same channel.
+type staticResolverBuilder struct {
+	scheme  string
+	addrs   []string
+	onBuild func(resolver.ClientConn)
+}
+
+func (b *staticResolverBuilder) Scheme() string {
+	return b.scheme
+}
+
+func (b *staticResolverBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	addresses := make([]resolver.Address, len(b.addrs))
+	for i, addr := range b.addrs {
+		addresses[i] = resolver.Address{Addr: addr}
+	}
+	if err := cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		return nil, err
+	}
+	if b.onBuild != nil {
+		b.onBuild(cc)
+	}
+	return &staticResolver{}, nil
+}
+
+type staticResolver struct{}
+
+func (r *staticResolver) ResolveNow(resolver.ResolveNowOptions) {}
+func (r *staticResolver) Close()                                {}
+
+// dialTarget derives the grpc.Dial target and any accompanying dial options
+// from opts.Endpoints, falling back to the OFFERS_SERVICE_HOST/PORT env vars
+// when no endpoints are configured. When more than one endpoint is given, it
+// registers a staticResolverBuilder and enables round_robin load balancing.
+// onResolverBuild, if non-nil, is only invoked in the multi-endpoint case,
+// once gRPC builds the registered resolver for this dial.
+func dialTarget(opts *ClientOption, onResolverBuild func(resolver.ClientConn)) (string, []grpc.DialOption) {
+	switch len(opts.Endpoints) {
+	case 0:
+		servicePort := os.Getenv("OFFERS_SERVICE_PORT")
+		if servicePort == "" {
+			servicePort = DEFAULT_SERVICE_PORT
+		}
+		serviceHost := os.Getenv("OFFERS_SERVICE_HOST")
+		if serviceHost == "" {
+			serviceHost = DEFAULT_SERVICE_HOST
+		}
+		return fmt.Sprintf("%s:%s", serviceHost, servicePort), nil
+	case 1:
+		return opts.Endpoints[0], nil
+	default:
+		scheme := uniqueResolverScheme(opts.ResolverScheme)
+
+		resolverRegisterMu.Lock()
+		resolver.Register(&staticResolverBuilder{scheme: scheme, addrs: opts.Endpoints, onBuild: onResolverBuild})
+		resolverRegisterMu.Unlock()
+
+		target := fmt.Sprintf("%s:///offers", scheme)
+		return target, []grpc.DialOption{
+			grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`),
+		}
+	}
+}
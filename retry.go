@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var defaultRetryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.DeadlineExceeded,
+	codes.ResourceExhausted,
+	codes.Aborted,
+}
+
+const (
+	defaultMaxRetries        = 3
+	defaultInitialBackoff    = 100 * time.Millisecond
+	defaultMaxBackoff        = 2 * time.Second
+	defaultBackoffMultiplier = 2.0
+	defaultJitter            = 0.2
+)
+
+// isRetryableCode reports whether code is one of the configured retryable codes.
+func isRetryableCode(code codes.Code, retryable []codes.Code) bool {
+	for _, c := range retryable {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBackoff computes the backoff duration for the given attempt (0-indexed),
+// capped at opts.MaxBackoff and randomized by +/- opts.Jitter.
+func nextBackoff(attempt int, opts *ClientOption) time.Duration {
+	d := float64(opts.InitialBackoff) * math.Pow(opts.BackoffMultiplier, float64(attempt))
+	if max := float64(opts.MaxBackoff); d > max {
+		d = max
+	}
+	if opts.Jitter > 0 {
+		delta := d * opts.Jitter
+		d = d - delta + rand.Float64()*2*delta
+	}
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(d)
+}
+
+// retryLoop runs invoke, retrying on retryable status codes per opts, honoring
+// ctx cancellation between attempts. It's the single implementation shared by
+// retryInvoke and RetryUnaryClientInterceptor so the two retry paths can't
+// drift apart.
+func retryLoop(ctx context.Context, opts *ClientOption, invoke func() error) error {
+	var err error
+	for attempt := 0; attempt <= opts.MaxRetries; attempt++ {
+		err = invoke()
+		if err == nil {
+			return nil
+		}
+
+		st, ok := status.FromError(err)
+		if !ok || !isRetryableCode(st.Code(), opts.RetryableCodes) {
+			return err
+		}
+		if attempt == opts.MaxRetries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(nextBackoff(attempt, opts)):
+		}
+	}
+	return err
+}
+
+// retryInvoke runs invoke, retrying on retryable status codes per ofc.opts,
+// honoring ctx cancellation between attempts.
+func (ofc *offersClient) retryInvoke(ctx context.Context, invoke func() error) error {
+	return retryLoop(ctx, ofc.opts, invoke)
+}
+
+// RetryUnaryClientInterceptor returns a grpc.UnaryClientInterceptor implementing
+// the same retry policy as retryInvoke, for callers that dial the offers service
+// directly instead of going through Client.
+func RetryUnaryClientInterceptor(opts *ClientOption) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		callOpts ...grpc.CallOption,
+	) error {
+		return retryLoop(ctx, opts, func() error {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		})
+	}
+}
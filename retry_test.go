@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testRetryOptions() *ClientOption {
+	opts := NewDefaultClientOption()
+	opts.InitialBackoff = time.Millisecond
+	opts.MaxBackoff = 5 * time.Millisecond
+	opts.Jitter = 0
+	return opts
+}
+
+func TestRetryLoopRetriesThenSucceeds(t *testing.T) {
+	opts := testRetryOptions()
+
+	calls := 0
+	err := retryLoop(context.Background(), opts, func() error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "not yet")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestRetryLoopExhaustsRetriesAndReturnsLastError(t *testing.T) {
+	opts := testRetryOptions()
+	opts.MaxRetries = 2
+
+	calls := 0
+	wantErr := status.Error(codes.Unavailable, "still down")
+	err := retryLoop(context.Background(), opts, func() error {
+		calls++
+		return wantErr
+	})
+	require.Equal(t, wantErr, err)
+	require.Equal(t, opts.MaxRetries+1, calls)
+}
+
+func TestRetryLoopNonRetryableReturnsOnFirstAttempt(t *testing.T) {
+	opts := testRetryOptions()
+
+	calls := 0
+	wantErr := status.Error(codes.InvalidArgument, "bad request")
+	err := retryLoop(context.Background(), opts, func() error {
+		calls++
+		return wantErr
+	})
+	require.Equal(t, wantErr, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestRetryLoopCtxCancellationAbortsBackoff(t *testing.T) {
+	opts := testRetryOptions()
+	opts.InitialBackoff = 50 * time.Millisecond
+	opts.MaxBackoff = 50 * time.Millisecond
+	opts.MaxRetries = 5
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	err := retryLoop(ctx, opts, func() error {
+		calls++
+		if calls == 1 {
+			cancel()
+		}
+		return status.Error(codes.Unavailable, "down")
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, calls)
+}
+
+func TestNextBackoffCappedAndJittered(t *testing.T) {
+	opts := NewDefaultClientOption()
+	opts.InitialBackoff = 100 * time.Millisecond
+	opts.MaxBackoff = 150 * time.Millisecond
+	opts.BackoffMultiplier = 2
+	opts.Jitter = 0.2
+
+	maxWithJitter := opts.MaxBackoff + time.Duration(float64(opts.MaxBackoff)*opts.Jitter)
+	for attempt := 0; attempt < 5; attempt++ {
+		d := nextBackoff(attempt, opts)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.LessOrEqual(t, d, maxWithJitter)
+	}
+}